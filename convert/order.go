@@ -0,0 +1,174 @@
+package convert
+
+import (
+	"fmt"
+	"strconv"
+
+	bfxv1 "github.com/bitfinexcom/bitfinex-api-go/v1"
+	"github.com/bitfinexcom/bitfinex-api-go/v2"
+)
+
+// OrderFromV1Order converts a bitfinex v1 type order to v2.
+func OrderFromV1Order(o bfxv1.Order) (*bitfinex.Order, error) {
+	out := &bitfinex.Order{}
+
+	out.ID = o.ID
+	out.Symbol = o.Symbol
+	out.Hidden = o.IsHidden
+
+	// v1 timestamps are epoch seconds with a fractional component (e.g.
+	// "1444444626.931"); MTSCreated/MTSUpdated are epoch milliseconds, so
+	// scale rather than truncate to keep the sub-second precision.
+	ts, err := strconv.ParseFloat(o.Timestamp, 64)
+	if err != nil {
+		return nil, err
+	}
+	out.MTSCreated = int64(ts * 1000)
+	out.MTSUpdated = out.MTSCreated
+
+	p, err := strconv.ParseFloat(o.Price, 64)
+	if err != nil {
+		return nil, err
+	}
+	out.Price = p
+
+	ap, err := strconv.ParseFloat(o.AvgExecutionPrice, 64)
+	if err != nil {
+		return nil, err
+	}
+	out.PriceAvg = ap
+
+	switch {
+	case o.IsCanceled:
+		out.Status = bitfinex.OrderStatusCanceled
+	case o.IsLive:
+		out.Status = bitfinex.OrderStatusActive
+	}
+
+	mul := 1
+	if o.Side == "sell" {
+		mul = -1
+	}
+	oa, err := strconv.ParseFloat(o.OriginalAmount, 64)
+	if err != nil {
+		return nil, err
+	}
+	out.AmountOrig = oa
+	or, err := strconv.ParseFloat(o.RemainingAmount, 64)
+	if err != nil {
+		return nil, err
+	}
+	out.Amount = or * float64(mul)
+
+	typ, err := orderTypeFromV1(o.Type)
+	if err != nil {
+		return nil, err
+	}
+	out.Type = typ
+
+	// v1's REST order object only carries IsLive/IsCanceled/IsHidden/
+	// WasForced - there's no post-only/OCO/reduce-only field to propagate,
+	// those are v2-only concepts expressed via Flags.
+	if o.IsHidden {
+		out.Flags |= bitfinex.OrderFlagHidden
+	}
+
+	//out.PlacedID = o.
+	return out, nil
+}
+
+// OrderToV1Order converts a bitfinex v2 type order to v1, the inverse of
+// OrderFromV1Order.
+func OrderToV1Order(o bitfinex.Order) (*bfxv1.Order, error) {
+	out := &bfxv1.Order{}
+
+	out.ID = o.ID
+	out.Symbol = o.Symbol
+	out.IsHidden = o.Hidden || o.Flags&bitfinex.OrderFlagHidden != 0
+
+	// Undo the *1000 scaling applied in OrderFromV1Order so the round trip
+	// reproduces the original fractional-second v1 timestamp string.
+	out.Timestamp = strconv.FormatFloat(float64(o.MTSCreated)/1000, 'f', -1, 64)
+
+	out.Price = strconv.FormatFloat(o.Price, 'f', -1, 64)
+	out.AvgExecutionPrice = strconv.FormatFloat(o.PriceAvg, 'f', -1, 64)
+
+	switch o.Status {
+	case bitfinex.OrderStatusCanceled:
+		out.IsCanceled = true
+	case bitfinex.OrderStatusActive:
+		out.IsLive = true
+	}
+
+	out.Side = "buy"
+	amount := o.Amount
+	if amount < 0 {
+		out.Side = "sell"
+		amount = -amount
+	}
+	out.OriginalAmount = strconv.FormatFloat(o.AmountOrig, 'f', -1, 64)
+	out.RemainingAmount = strconv.FormatFloat(amount, 'f', -1, 64)
+
+	typ, err := orderTypeToV1(o.Type)
+	if err != nil {
+		return nil, err
+	}
+	out.Type = typ
+
+	return out, nil
+}
+
+// orderTypeFromV1 maps a v1 order type string to its v2 bitfinex.OrderType.
+func orderTypeFromV1(t string) (string, error) {
+	switch t {
+	case "market":
+		return bitfinex.OrderTypeMarket, nil
+	case "exchange market":
+		return bitfinex.OrderTypeExchangeMarket, nil
+	case "limit":
+		return bitfinex.OrderTypeLimit, nil
+	case "exchange limit":
+		return bitfinex.OrderTypeExchangeLimit, nil
+	case "stop":
+		return bitfinex.OrderTypeStop, nil
+	case "exchange stop":
+		return bitfinex.OrderTypeExchangeStop, nil
+	case "trailing-stop":
+		return bitfinex.OrderTypeTrailingStop, nil
+	case "exchange trailing-stop":
+		return bitfinex.OrderTypeExchangeTrailingStop, nil
+	case "fill-or-kill":
+		return bitfinex.OrderTypeFOK, nil
+	case "exchange fill-or-kill":
+		return bitfinex.OrderTypeExchangeFOK, nil
+	}
+	return "", fmt.Errorf("unrecognized v1 order type: %q", t)
+}
+
+// orderTypeToV1 maps a v2 bitfinex.OrderType to its v1 order type string,
+// the inverse of orderTypeFromV1.
+func orderTypeToV1(t string) (string, error) {
+	switch t {
+	case bitfinex.OrderTypeMarket:
+		return "market", nil
+	case bitfinex.OrderTypeExchangeMarket:
+		return "exchange market", nil
+	case bitfinex.OrderTypeLimit:
+		return "limit", nil
+	case bitfinex.OrderTypeExchangeLimit:
+		return "exchange limit", nil
+	case bitfinex.OrderTypeStop:
+		return "stop", nil
+	case bitfinex.OrderTypeExchangeStop:
+		return "exchange stop", nil
+	case bitfinex.OrderTypeTrailingStop:
+		return "trailing-stop", nil
+	case bitfinex.OrderTypeExchangeTrailingStop:
+		return "exchange trailing-stop", nil
+	case bitfinex.OrderTypeFOK:
+		return "fill-or-kill", nil
+	case bitfinex.OrderTypeExchangeFOK:
+		return "exchange fill-or-kill", nil
+	}
+	return "", fmt.Errorf("unrecognized v2 order type: %q", t)
+}
@@ -0,0 +1,117 @@
+package convert
+
+import (
+	"strconv"
+	"testing"
+
+	bfxv1 "github.com/bitfinexcom/bitfinex-api-go/v1"
+	"github.com/bitfinexcom/bitfinex-api-go/v2"
+)
+
+func TestOrderFromV1OrderRoundTrip(t *testing.T) {
+	cases := []struct {
+		name string
+		in   bfxv1.Order
+	}{
+		{
+			name: "limit",
+			in: bfxv1.Order{
+				ID:                1,
+				Symbol:            "btcusd",
+				Type:              "limit",
+				Side:              "buy",
+				IsLive:            true,
+				Timestamp:         "1444444626.931",
+				Price:             "100.5",
+				AvgExecutionPrice: "0",
+				OriginalAmount:    "1.0",
+				RemainingAmount:   "1.0",
+			},
+		},
+		{
+			name: "exchange market sell hidden",
+			in: bfxv1.Order{
+				ID:                2,
+				Symbol:            "ethusd",
+				Type:              "exchange market",
+				Side:              "sell",
+				IsCanceled:        true,
+				IsHidden:          true,
+				Timestamp:         "1500000000.123",
+				Price:             "0",
+				AvgExecutionPrice: "205.25",
+				OriginalAmount:    "2.0",
+				RemainingAmount:   "0.5",
+			},
+		},
+		{
+			name: "fill-or-kill",
+			in: bfxv1.Order{
+				ID:                3,
+				Symbol:            "btcusd",
+				Type:              "fill-or-kill",
+				Side:              "buy",
+				Timestamp:         "1600000000.0",
+				Price:             "9000",
+				AvgExecutionPrice: "0",
+				OriginalAmount:    "0.1",
+				RemainingAmount:   "0.1",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v2Order, err := OrderFromV1Order(c.in)
+			if err != nil {
+				t.Fatalf("OrderFromV1Order: %s", err)
+			}
+			if v2Order.MTSCreated != v2Order.MTSUpdated {
+				t.Errorf("MTSCreated != MTSUpdated: %d != %d", v2Order.MTSCreated, v2Order.MTSUpdated)
+			}
+
+			back, err := OrderToV1Order(*v2Order)
+			if err != nil {
+				t.Fatalf("OrderToV1Order: %s", err)
+			}
+
+			if back.ID != c.in.ID {
+				t.Errorf("ID = %d, want %d", back.ID, c.in.ID)
+			}
+			if back.Symbol != c.in.Symbol {
+				t.Errorf("Symbol = %q, want %q", back.Symbol, c.in.Symbol)
+			}
+			if back.Type != c.in.Type {
+				t.Errorf("Type = %q, want %q", back.Type, c.in.Type)
+			}
+			if back.Side != c.in.Side {
+				t.Errorf("Side = %q, want %q", back.Side, c.in.Side)
+			}
+			if back.IsHidden != c.in.IsHidden {
+				t.Errorf("IsHidden = %v, want %v", back.IsHidden, c.in.IsHidden)
+			}
+			wantTS, _ := strconv.ParseFloat(c.in.Timestamp, 64)
+			gotTS, err := strconv.ParseFloat(back.Timestamp, 64)
+			if err != nil {
+				t.Fatalf("back.Timestamp not a float: %s", err)
+			}
+			if diff := gotTS - wantTS; diff > 1e-3 || diff < -1e-3 {
+				t.Errorf("Timestamp = %q, want %q (millisecond precision should round-trip)", back.Timestamp, c.in.Timestamp)
+			}
+		})
+	}
+}
+
+func TestOrderFromV1OrderUnrecognizedType(t *testing.T) {
+	_, err := OrderFromV1Order(bfxv1.Order{Type: "bogus", Timestamp: "0", Price: "0", AvgExecutionPrice: "0", OriginalAmount: "0", RemainingAmount: "0"})
+	if err == nil {
+		t.Fatal("expected an error for an unrecognized v1 order type")
+	}
+}
+
+func TestOrderToV1OrderUnrecognizedType(t *testing.T) {
+	_, err := OrderToV1Order(bitfinex.Order{Type: "bogus"})
+	if err == nil {
+		t.Fatal("expected an error for an unrecognized v2 order type")
+	}
+}
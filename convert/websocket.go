@@ -1,11 +1,5 @@
 package convert
 
-import (
-	bfxv1 "github.com/bitfinexcom/bitfinex-api-go/v1"
-	"github.com/bitfinexcom/bitfinex-api-go/v2"
-	"strconv"
-)
-
 // converts messages from FIX to bitfinex
 // Bitfinex types.
 
@@ -40,69 +34,3 @@ func StringOrEmpty(i interface{}) string {
 	}
 	return ""
 }
-
-// OrderFromV1Order converts a bitfinex v1 type order to v2
-func OrderFromV1Order(o bfxv1.Order) (*bitfinex.Order, error) {
-	out := &bitfinex.Order{}
-
-	out.ID = o.ID
-	out.Symbol = o.Symbol
-	out.Hidden = o.IsHidden
-
-	ts, err := strconv.ParseFloat(o.Timestamp, 64)
-	if err != nil {
-		return nil, err
-	}
-	out.MTSCreated = int64(ts)
-	out.MTSUpdated = int64(ts)
-
-	p, err := strconv.ParseFloat(o.Price, 64)
-	if err != nil {
-		return nil, err
-	}
-	out.Price = p
-
-	ap, err := strconv.ParseFloat(o.AvgExecutionPrice, 64)
-	if err != nil {
-		return nil, err
-	}
-	out.PriceAvg = ap
-
-	switch {
-	case o.IsCanceled:
-		out.Status = bitfinex.OrderStatusCanceled
-	case o.IsLive:
-		out.Status = bitfinex.OrderStatusActive
-	}
-
-	mul := 1
-	if o.Side == "sell" {
-		mul = -1
-	}
-	oa, err := strconv.ParseFloat(o.OriginalAmount, 64)
-	if err != nil {
-		return nil, err
-	}
-	out.AmountOrig = oa
-	or, err := strconv.ParseFloat(o.RemainingAmount, 64)
-	if err != nil {
-		return nil, err
-	}
-	out.Amount = or * float64(mul)
-
-	switch o.Type {
-	case "market":
-		out.Type = bitfinex.OrderTypeMarket
-	case "limit":
-		out.Type = bitfinex.OrderTypeLimit
-	case "exchange limit":
-		out.Type = bitfinex.OrderTypeExchangeLimit
-	case "stop":
-		out.Type = bitfinex.OrderTypeStop
-	case "trailing-stop":
-		out.Type = bitfinex.OrderTypeTrailingStop
-	}
-
-	//out.PlacedID = o.
-	return out, nil
-}
@@ -0,0 +1,74 @@
+package fix
+
+import (
+	"log"
+
+	"github.com/michaelwilner/bfxfixgw/service/symbol"
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix43/securitylist"
+	"github.com/quickfixgo/fix43/securitylistrequest"
+	"github.com/quickfixgo/quickfix"
+	"github.com/shopspring/decimal"
+)
+
+// securityLister is implemented by Symbology sources that can also enumerate
+// the security definitions known for a counterparty. It's optional - not
+// every Symbology (e.g. a remote HTTP source) need implement it - so
+// SecurityListHandler type-asserts for it rather than requiring it on the
+// Symbology interface itself.
+type securityLister interface {
+	SecurityList(counterparty string) ([]symbol.SecurityDefinition, error)
+}
+
+// SecurityListHandler answers FIX SecurityListRequest (MsgType=x) messages
+// with a SecurityList (MsgType=y) built from a symbol.Symbology, one
+// NoRelatedSym group entry per symbol known for the requesting counterparty.
+//
+// SecurityListRequest/SecurityList were introduced in FIX 4.3, so this
+// handler speaks fix43 rather than the gateway's usual fix42 dialect.
+type SecurityListHandler struct {
+	Symbology symbol.Symbology
+}
+
+// OnSecurityListRequest handles an inbound FIX43 SecurityListRequest.
+func (h *SecurityListHandler) OnSecurityListRequest(msg securitylistrequest.SecurityListRequest, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+	reqID, err := msg.GetSecurityReqID()
+	if err != nil {
+		return err
+	}
+
+	lister, ok := h.Symbology.(securityLister)
+	if !ok {
+		return quickfix.NewMessageRejectError("security list is not supported by the configured symbology source", 0, nil)
+	}
+
+	counterparty := sessionID.TargetCompID
+	defs, listErr := lister.SecurityList(counterparty)
+	if listErr != nil {
+		return quickfix.NewMessageRejectError(listErr.Error(), 0, nil)
+	}
+
+	group := securitylist.NewNoRelatedSymRepeatingGroup()
+	for _, def := range defs {
+		entry := group.Add()
+		entry.SetSymbol(def.Symbol)
+		entry.SetMinTradeVol(decimal.NewFromFloat(def.MinimumOrderSize), 2)
+		entry.SetRoundLot(decimal.NewFromInt(1), 0)
+	}
+
+	res := securitylist.New(
+		field.NewSecurityReqID(reqID),
+		field.NewSecurityResponseID(reqID),
+		field.NewSecurityRequestResult(enum.SecurityRequestResult_VALID_REQUEST),
+	)
+	res.SetNoRelatedSym(group)
+
+	// SendToTarget returns a plain error, not a quickfix.MessageRejectError -
+	// a delivery failure here isn't a reason to reject the inbound request,
+	// so log it rather than trying to shoehorn it into the return type.
+	if err := quickfix.SendToTarget(res, sessionID); err != nil {
+		log.Printf("could not send SecurityList to %s: %s", sessionID, err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package symbol
+
+import "fmt"
+
+// CompositeSymbology tries each underlying Symbology in priority order,
+// falling back to the next on error. Useful for e.g. preferring a local
+// file override over a shared remote service.
+type CompositeSymbology struct {
+	sources []Symbology
+}
+
+// NewCompositeSymbology chains the given sources in priority order.
+func NewCompositeSymbology(sources ...Symbology) *CompositeSymbology {
+	return &CompositeSymbology{sources: sources}
+}
+
+// ToBitfinex converts symbol to Bitfinex form, trying each source in order.
+func (c *CompositeSymbology) ToBitfinex(symbol, counterparty string) (string, error) {
+	var err error
+	for _, s := range c.sources {
+		var sym string
+		if sym, err = s.ToBitfinex(symbol, counterparty); err == nil {
+			return sym, nil
+		}
+	}
+	return "", firstOrNoSources(err)
+}
+
+// FromBitfinex converts symbol from Bitfinex form, trying each source in order.
+func (c *CompositeSymbology) FromBitfinex(symbol, counterparty string) (string, error) {
+	var err error
+	for _, s := range c.sources {
+		var sym string
+		if sym, err = s.FromBitfinex(symbol, counterparty); err == nil {
+			return sym, nil
+		}
+	}
+	return "", firstOrNoSources(err)
+}
+
+// Translate converts symbol from counterparty src's form to counterparty
+// dst's form, routing through Bitfinex's native symbol.
+func (c *CompositeSymbology) Translate(src, dst, symbol string) (string, error) {
+	return translate(c, src, dst, symbol)
+}
+
+func firstOrNoSources(err error) error {
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("no symbology sources configured")
+}
@@ -0,0 +1,67 @@
+package symbol
+
+import "strings"
+
+// CurrencyPair is a decomposed base/quote asset pair, e.g. BTC/USD.
+type CurrencyPair struct {
+	Base  string
+	Quote string
+}
+
+// multiCharAssets lists known asset codes longer than the common 3-letter
+// length, tried before a plain 3/3 split so ambiguous Bitfinex symbols
+// decompose correctly: tLUNAUSD is LUNA/USD, not LUN/AUSD, and tUSTUSDT is
+// UST/USDT, not USTU/SDT. Entries are matched longest-first (see
+// DecomposeBitfinexSymbol) so codes that collide as a prefix/suffix of each
+// other, like LUNA and LUNA2, still resolve to the longer, more specific one.
+var multiCharAssets = []string{"USDT", "LUNA2", "LUNA", "DOGE", "LINK"}
+
+// DecomposeBitfinexSymbol splits a Bitfinex symbol (with or without the
+// leading "t") into its base/quote CurrencyPair. It prefers known
+// multi-character asset codes over a plain 3/3 split, and among those
+// prefers the longest match, so a shorter code that happens to prefix or
+// suffix a longer one (LUNA vs LUNA2) doesn't win by accident.
+func DecomposeBitfinexSymbol(symbol string) (CurrencyPair, bool) {
+	sym := strings.TrimPrefix(symbol, "t")
+
+	best := CurrencyPair{}
+	bestLen := -1
+	for _, asset := range multiCharAssets {
+		if len(asset) <= bestLen {
+			continue
+		}
+		if strings.HasPrefix(sym, asset) && len(sym) > len(asset) {
+			best, bestLen = CurrencyPair{Base: asset, Quote: sym[len(asset):]}, len(asset)
+		}
+		if strings.HasSuffix(sym, asset) && len(sym) > len(asset) {
+			best, bestLen = CurrencyPair{Base: sym[:len(sym)-len(asset)], Quote: asset}, len(asset)
+		}
+	}
+	if bestLen >= 0 {
+		return best, true
+	}
+
+	if len(sym) == 6 {
+		return CurrencyPair{Base: sym[:3], Quote: sym[3:]}, true
+	}
+	return CurrencyPair{}, false
+}
+
+// BitfinexSymbol reconstructs the Bitfinex symbol for the pair, e.g. tBTCUSD.
+func (p CurrencyPair) BitfinexSymbol() string {
+	return "t" + p.Base + p.Quote
+}
+
+// String renders the pair in ISO-style base/quote notation, e.g. BTC/USD.
+func (p CurrencyPair) String() string {
+	return p.Base + "/" + p.Quote
+}
+
+// ParseCurrencyPair parses an ISO-style "BASE/QUOTE" string into a CurrencyPair.
+func ParseCurrencyPair(s string) (CurrencyPair, bool) {
+	parts := strings.SplitN(s, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return CurrencyPair{}, false
+	}
+	return CurrencyPair{Base: parts[0], Quote: parts[1]}, true
+}
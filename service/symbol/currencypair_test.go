@@ -0,0 +1,55 @@
+package symbol
+
+import "testing"
+
+func TestDecomposeBitfinexSymbol(t *testing.T) {
+	cases := []struct {
+		symbol string
+		base   string
+		quote  string
+	}{
+		{"tBTCUSD", "BTC", "USD"},
+		{"BTCUSD", "BTC", "USD"},
+		{"tLUNAUSD", "LUNA", "USD"},
+		{"tDOGEUSD", "DOGE", "USD"},
+		{"tUSDTUSD", "USDT", "USD"},
+		{"tBTCUSDT", "BTC", "USDT"},
+		// Ambiguous: UST is a plain 3-letter asset next to the known
+		// 4-letter USDT, which matches as a suffix here - the split must
+		// follow USDT rather than give USTU/SDT.
+		{"tUSTUSDT", "UST", "USDT"},
+		// Ambiguous: LUNA is itself a prefix of the known longer code LUNA2
+		// (Bitfinex's real post-crash ticker) - the longer match must win so
+		// this doesn't decompose as LUNA/2USD.
+		{"tLUNA2USD", "LUNA2", "USD"},
+	}
+	for _, c := range cases {
+		pair, ok := DecomposeBitfinexSymbol(c.symbol)
+		if !ok {
+			t.Errorf("DecomposeBitfinexSymbol(%q): expected a match", c.symbol)
+			continue
+		}
+		if pair.Base != c.base || pair.Quote != c.quote {
+			t.Errorf("DecomposeBitfinexSymbol(%q) = %s/%s, want %s/%s", c.symbol, pair.Base, pair.Quote, c.base, c.quote)
+		}
+	}
+}
+
+func TestCurrencyPairRoundTrip(t *testing.T) {
+	pair, ok := ParseCurrencyPair("BTC/USD")
+	if !ok {
+		t.Fatal("ParseCurrencyPair(\"BTC/USD\"): expected a match")
+	}
+	if got := pair.BitfinexSymbol(); got != "tBTCUSD" {
+		t.Errorf("BitfinexSymbol() = %q, want %q", got, "tBTCUSD")
+	}
+	if got := pair.String(); got != "BTC/USD" {
+		t.Errorf("String() = %q, want %q", got, "BTC/USD")
+	}
+}
+
+func TestParseCurrencyPairInvalid(t *testing.T) {
+	if _, ok := ParseCurrencyPair("BTCUSD"); ok {
+		t.Error("ParseCurrencyPair(\"BTCUSD\"): expected no match without a delimiter")
+	}
+}
@@ -0,0 +1,212 @@
+package symbol
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SecurityDefinition holds the tradable-instrument metadata the gateway needs
+// to answer a FIX SecurityListRequest, sourced from Bitfinex's v1
+// /symbols_details endpoint.
+type SecurityDefinition struct {
+	Symbol           string  // Bitfinex symbol, e.g. "tBTCUSD"
+	PricePrecision   int     // number of significant digits for price
+	InitialMargin    float64 // percent
+	MinimumMargin    float64 // percent
+	MaximumOrderSize float64
+	MinimumOrderSize float64
+	Margin           bool
+}
+
+// symbolsDetailsEntry mirrors a single element of the v1 /symbols_details response.
+type symbolsDetailsEntry struct {
+	Pair             string `json:"pair"`
+	PricePrecision   int    `json:"price_precision"`
+	InitialMargin    string `json:"initial_margin"`
+	MinimumMargin    string `json:"minimum_margin"`
+	MaximumOrderSize string `json:"maximum_order_size"`
+	MinimumOrderSize string `json:"minimum_order_size"`
+	Margin           bool   `json:"margin"`
+}
+
+const v1SymbolsDetailsURL = "https://api.bitfinex.com/v1/symbols_details"
+
+// FetchSecurityDefinitions pulls product metadata from the Bitfinex v1
+// /symbols_details endpoint. The returned definitions are keyed by Bitfinex
+// symbol in its v2 form: the v1 pair upper-cased with a leading "t"
+// (e.g. "btcusd" becomes "tBTCUSD").
+func FetchSecurityDefinitions() (map[string]SecurityDefinition, error) {
+	resp, err := http.Get(v1SymbolsDetailsURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var entries []symbolsDetailsEntry
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return nil, err
+	}
+	return definitionsFromEntries(entries), nil
+}
+
+func definitionsFromEntries(entries []symbolsDetailsEntry) map[string]SecurityDefinition {
+	defs := make(map[string]SecurityDefinition, len(entries))
+	for _, e := range entries {
+		sym := "t" + strings.ToUpper(e.Pair)
+		defs[sym] = SecurityDefinition{
+			Symbol:           sym,
+			PricePrecision:   e.PricePrecision,
+			InitialMargin:    parseFloatOrZero(e.InitialMargin),
+			MinimumMargin:    parseFloatOrZero(e.MinimumMargin),
+			MaximumOrderSize: parseFloatOrZero(e.MaximumOrderSize),
+			MinimumOrderSize: parseFloatOrZero(e.MinimumOrderSize),
+			Margin:           e.Margin,
+		}
+	}
+	return defs
+}
+
+// loadDefinitionsCache reads a previously cached set of definitions from disk.
+func loadDefinitionsCache(path string) (map[string]SecurityDefinition, error) {
+	body, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defs := make(map[string]SecurityDefinition)
+	if err := json.Unmarshal(body, &defs); err != nil {
+		return nil, err
+	}
+	return defs, nil
+}
+
+// saveDefinitionsCache writes the current set of definitions to disk so a
+// restart doesn't require reaching the Bitfinex API before serving a
+// SecurityListRequest.
+func saveDefinitionsCache(path string, defs map[string]SecurityDefinition) error {
+	body, err := json.Marshal(defs)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, body, 0644)
+}
+
+// RefreshDefinitions fetches the latest product metadata from Bitfinex,
+// caches it at cachePath, and installs it for use by SecurityList/Lookup.
+// On fetch failure the existing on-disk cache, if any, is left untouched.
+func (f *FileSymbology) RefreshDefinitions(cachePath string) error {
+	defs, err := FetchSecurityDefinitions()
+	if err != nil {
+		return err
+	}
+	if cachePath != "" {
+		if err := saveDefinitionsCache(cachePath, defs); err != nil {
+			log.Printf("could not write security definitions cache %q: %s", cachePath, err)
+		}
+	}
+	f.lock.Lock()
+	f.definitions = defs
+	f.lock.Unlock()
+	return nil
+}
+
+// LoadDefinitionsCache installs product metadata from an on-disk cache
+// written by a previous RefreshDefinitions call, without contacting Bitfinex.
+func (f *FileSymbology) LoadDefinitionsCache(cachePath string) error {
+	defs, err := loadDefinitionsCache(cachePath)
+	if err != nil {
+		return err
+	}
+	f.lock.Lock()
+	f.definitions = defs
+	f.lock.Unlock()
+	return nil
+}
+
+// StartDefinitionsRefresh loads the on-disk cache if present, then refreshes
+// product metadata from Bitfinex on startup and every interval thereafter.
+// It returns a stop function that halts the background refresh.
+func (f *FileSymbology) StartDefinitionsRefresh(cachePath string, interval time.Duration) (stop func()) {
+	if cachePath != "" {
+		if err := f.LoadDefinitionsCache(cachePath); err != nil {
+			log.Printf("no usable security definitions cache at %q: %s", cachePath, err)
+		}
+	}
+	done := make(chan struct{})
+	go func() {
+		if err := f.RefreshDefinitions(cachePath); err != nil {
+			log.Printf("could not refresh security definitions: %s", err)
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := f.RefreshDefinitions(cachePath); err != nil {
+					log.Printf("could not refresh security definitions: %s", err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// Lookup returns the security definition for a Bitfinex symbol, if known.
+func (f *FileSymbology) Lookup(bitfinexSymbol string) (SecurityDefinition, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	def, ok := f.definitions[bitfinexSymbol]
+	return def, ok
+}
+
+// SecurityList returns the security definitions for every Bitfinex symbol
+// explicitly mapped for the given counterparty, for use in a FIX
+// SecurityList response. Symbols with no known definition are omitted.
+//
+// Only explicit symbol= mappings are enumerated. A counterparty configured
+// purely with rule=/pairs= matching (see rules.go, currencypair.go)
+// describes an open-ended symbol space that can't be listed, so it comes
+// back as an empty slice with a nil error rather than an error - callers
+// must not read an empty SecurityList as "this counterparty has no
+// tradable symbols".
+func (f *FileSymbology) SecurityList(counterparty string) ([]SecurityDefinition, error) {
+	f.lock.Lock()
+	symset, ok := f.counterparties[counterparty]
+	if !ok {
+		f.lock.Unlock()
+		return nil, fmt.Errorf("could not find counterparty: %s", counterparty)
+	}
+	bfxSymbols := make([]string, 0, len(symset.symbols))
+	for bfx := range symset.symbols {
+		bfxSymbols = append(bfxSymbols, bfx)
+	}
+	ruleBased := len(symset.rules) > 0 || symset.pairs
+	f.lock.Unlock()
+
+	if len(bfxSymbols) == 0 && ruleBased {
+		log.Printf("counterparty %q has no explicit symbol mappings to list; its symbols are matched by rule/pairs and cannot be enumerated", counterparty)
+	}
+
+	defs := make([]SecurityDefinition, 0, len(bfxSymbols))
+	for _, bfx := range bfxSymbols {
+		if def, ok := f.Lookup(bfx); ok {
+			defs = append(defs, def)
+		}
+	}
+	return defs, nil
+}
+
+func parseFloatOrZero(s string) float64 {
+	var f float64
+	fmt.Sscanf(s, "%f", &f)
+	return f
+}
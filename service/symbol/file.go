@@ -1,82 +1,127 @@
 package symbol
 
 import (
-	"bufio"
 	"fmt"
 	"log"
-	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 )
 
-// symbolset is the bitfinex symbol
+// symbolset is a counterparty's mapping between Bitfinex symbols and
+// counterparty symbols. Both directions are kept up to date on every write
+// so ToBitfinex/FromBitfinex are O(1) lookups instead of scans.
 type symbolset struct {
-	symbols     map[string]string
+	symbols     map[string]string // bitfinex symbol -> counterparty symbol
+	reverse     map[string]string // counterparty symbol -> bitfinex symbol
+	rules       []symbolRule
+	pairs       bool
 	passthrough bool
 }
 
 func newSymbolset() *symbolset {
 	return &symbolset{
 		symbols: make(map[string]string),
+		reverse: make(map[string]string),
 	}
 }
 
-func (s *symbolset) set(k, v string) {
-	s.symbols[k] = v
+func (s *symbolset) set(bfx, cp string) {
+	s.symbols[bfx] = cp
+	s.reverse[cp] = bfx
 }
 
-func (s *symbolset) get(k string) (string, bool) {
-	sym, ok := s.symbols[k]
-	return sym, ok
+// get looks up the counterparty symbol for a Bitfinex symbol.
+func (s *symbolset) get(bfx string) (string, bool) {
+	cp, ok := s.symbols[bfx]
+	return cp, ok
 }
 
-// FileSymbology parses a simple KVP symbology mapping.  Counterparty names are wrapped with [square brackets] and prefix a symbol mapping set.
-// L-values are Bitfinex symbols, R-values are counterparty symbols.
+// getReverse looks up the Bitfinex symbol for a counterparty symbol.
+func (s *symbolset) getReverse(cp string) (string, bool) {
+	bfx, ok := s.reverse[cp]
+	return bfx, ok
+}
+
+// FileSymbology parses a symbology mapping from disk in KVP, YAML, or JSON
+// form (auto-detected from the file extension; anything other than .yml,
+// .yaml, or .json is treated as KVP) and reloads it automatically whenever
+// the file changes.
+//
+// For KVP files, counterparty names are wrapped with [square brackets] and
+// prefix a symbol mapping set. L-values are Bitfinex symbols, R-values are
+// counterparty symbols.
 // ex:
 // [Bloomberg]
 // tBTCUSD=BXY
 type FileSymbology struct {
-	counterparty   string
+	path           string
 	counterparties map[string]*symbolset
+	definitions    map[string]SecurityDefinition
+	subscribers    []chan Event
+	watcher        *fsWatcher
 	lock           sync.Mutex
 }
 
-func (f *FileSymbology) parse(line string) {
-	if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
-		f.counterparty = line[1 : len(line)-1]
-	}
-	s := strings.Split(line, "=")
-	if len(s) < 2 {
-		return
+// NewFileSymbology creates a new file symbology object from a given path and
+// begins watching it for changes.
+func NewFileSymbology(path string) (*FileSymbology, error) {
+	counterparties, err := loadSymbology(path)
+	if err != nil {
+		return nil, err
 	}
-	symbols, ok := f.counterparties[f.counterparty]
-	if !ok {
-		symbols = newSymbolset()
-		f.counterparties[f.counterparty] = symbols
+	f := &FileSymbology{
+		path:           path,
+		counterparties: counterparties,
 	}
-	if strings.ToLower(s[0]) == "passthrough" && strings.ToLower(s[1]) == "true" {
-		symbols.passthrough = true
+	watcher, err := startWatching(path, f.reload)
+	if err != nil {
+		log.Printf("could not watch symbology file %q for changes: %s", path, err)
 	} else {
-		symbols.set(s[0], s[1])
+		f.watcher = watcher
 	}
+	return f, nil
 }
 
-// NewFileSymbology creates a new file symbology object from a given path
-func NewFileSymbology(path string) (*FileSymbology, error) {
-	f, err := os.Open(path)
+// Close stops watching the underlying symbology file for changes.
+func (f *FileSymbology) Close() error {
+	if f.watcher == nil {
+		return nil
+	}
+	return f.watcher.Close()
+}
+
+// reload re-parses the symbology file and atomically swaps it in, then
+// notifies subscribers. It's safe to call concurrently with ToBitfinex/
+// FromBitfinex - in-flight lookups always see either the old or new mapping,
+// never a partially-built one.
+func (f *FileSymbology) reload(path string) {
+	counterparties, err := loadSymbology(path)
 	if err != nil {
-		return nil, err
+		log.Printf("could not reload symbology %q: %s", path, err)
+		return
 	}
-	s := &FileSymbology{counterparties: make(map[string]*symbolset)}
-	scanner := bufio.NewScanner(f)
-	scanner.Split(bufio.ScanLines)
-	for scanner.Scan() {
-		s.parse(scanner.Text())
+	f.lock.Lock()
+	f.counterparties = counterparties
+	f.lock.Unlock()
+	f.notify(Event{Type: EventReload, Path: path})
+}
+
+// loadSymbology parses a symbology file, dispatching on file extension.
+func loadSymbology(path string) (map[string]*symbolset, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yml", ".yaml":
+		return loadYAML(path)
+	case ".json":
+		return loadJSON(path)
+	default:
+		return loadKVP(path)
 	}
-	return s, f.Close()
 }
 
-// ToBitfinex converts symbol to Bitfinex form
+// ToBitfinex converts symbol to Bitfinex form. Explicit mappings are tried
+// first, then pairs-mode ISO decomposition (e.g. BTC/USD -> tBTCUSD), then
+// passthrough.
 func (f *FileSymbology) ToBitfinex(symbol, counterparty string) (string, error) {
 	f.lock.Lock()
 	defer f.lock.Unlock()
@@ -85,19 +130,24 @@ func (f *FileSymbology) ToBitfinex(symbol, counterparty string) (string, error)
 		log.Printf("could not find counterparty: %s", counterparty)
 		return "", fmt.Errorf("could not find counterparty: %s", counterparty)
 	}
-	if symset.passthrough {
-		return symbol, nil
+	if bfx, ok := symset.getReverse(symbol); ok {
+		return bfx, nil
 	}
-	for bfx, cp := range symset.symbols {
-		if cp == symbol {
-			return bfx, nil
+	if symset.pairs {
+		if cp, ok := ParseCurrencyPair(symbol); ok {
+			return cp.BitfinexSymbol(), nil
 		}
 	}
+	if symset.passthrough {
+		return symbol, nil
+	}
 	log.Printf("could not find Bitfinex symbol mapping \"%s\" for counterparty \"%s\"", symbol, counterparty)
 	return "", fmt.Errorf("could not find Bitfinex symbol mapping \"%s\" for counterparty \"%s\"", symbol, counterparty)
 }
 
-// FromBitfinex converts symbol from Bitfinex form
+// FromBitfinex converts symbol from Bitfinex form. Explicit mappings are
+// tried first, then regex/template rules and pairs-mode ISO decomposition
+// (e.g. tBTCUSD -> BTC/USD), then passthrough.
 func (f *FileSymbology) FromBitfinex(symbol, counterparty string) (string, error) {
 	f.lock.Lock()
 	defer f.lock.Unlock()
@@ -105,12 +155,25 @@ func (f *FileSymbology) FromBitfinex(symbol, counterparty string) (string, error
 	if !ok {
 		return "", fmt.Errorf("could not find counterparty: %s", counterparty)
 	}
+	if sym, ok := symset.get(symbol); ok {
+		return sym, nil
+	}
+	if sym, ok := matchRules(symset.rules, symbol); ok {
+		return sym, nil
+	}
+	if symset.pairs {
+		if cp, ok := DecomposeBitfinexSymbol(symbol); ok {
+			return cp.String(), nil
+		}
+	}
 	if symset.passthrough {
 		return symbol, nil
 	}
-	sym, ok := symset.get(symbol)
-	if !ok {
-		return "", fmt.Errorf("could not find symbol \"%s\" for counterparty \"%s\"", symbol, counterparty)
-	}
-	return sym, nil
+	return "", fmt.Errorf("could not find symbol \"%s\" for counterparty \"%s\"", symbol, counterparty)
+}
+
+// Translate converts symbol from counterparty src's form to counterparty
+// dst's form, routing through Bitfinex's native symbol.
+func (f *FileSymbology) Translate(src, dst, symbol string) (string, error) {
+	return translate(f, src, dst, symbol)
 }
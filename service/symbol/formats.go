@@ -0,0 +1,119 @@
+package symbol
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// counterpartyConfig is the structured (YAML/JSON) equivalent of a KVP
+// counterparty section.
+type counterpartyConfig struct {
+	Passthrough bool              `yaml:"passthrough" json:"passthrough"`
+	Pairs       bool              `yaml:"pairs" json:"pairs"`
+	Rules       []string          `yaml:"rules" json:"rules"`
+	Symbols     map[string]string `yaml:"symbols" json:"symbols"`
+}
+
+// loadKVP parses the original bracketed-KVP symbology format.
+// ex:
+// [Bloomberg]
+// tBTCUSD=BXY
+func loadKVP(path string) (map[string]*symbolset, error) {
+	fh, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+
+	counterparties := make(map[string]*symbolset)
+	var current string
+
+	scanner := bufio.NewScanner(fh)
+	scanner.Split(bufio.ScanLines)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			current = line[1 : len(line)-1]
+			continue
+		}
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) < 2 || current == "" {
+			continue
+		}
+		symset, ok := counterparties[current]
+		if !ok {
+			symset = newSymbolset()
+			counterparties[current] = symset
+		}
+		switch strings.ToLower(kv[0]) {
+		case "passthrough":
+			symset.passthrough = strings.ToLower(kv[1]) == "true"
+		case "pairs":
+			symset.pairs = strings.ToLower(kv[1]) == "true"
+		case "rule":
+			rule, err := parseRule(kv[1])
+			if err != nil {
+				log.Printf("skipping malformed rule for %q in %q: %s", current, path, err)
+				continue
+			}
+			symset.rules = append(symset.rules, rule)
+		default:
+			symset.set(kv[0], kv[1])
+		}
+	}
+	return counterparties, scanner.Err()
+}
+
+// loadYAML parses a counterparty-name-keyed YAML symbology file.
+func loadYAML(path string) (map[string]*symbolset, error) {
+	body, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := make(map[string]counterpartyConfig)
+	if err := yaml.Unmarshal(body, &cfg); err != nil {
+		return nil, err
+	}
+	return counterpartiesFromConfig(cfg), nil
+}
+
+// loadJSON parses a counterparty-name-keyed JSON symbology file.
+func loadJSON(path string) (map[string]*symbolset, error) {
+	body, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := make(map[string]counterpartyConfig)
+	if err := json.Unmarshal(body, &cfg); err != nil {
+		return nil, err
+	}
+	return counterpartiesFromConfig(cfg), nil
+}
+
+func counterpartiesFromConfig(cfg map[string]counterpartyConfig) map[string]*symbolset {
+	counterparties := make(map[string]*symbolset, len(cfg))
+	for name, c := range cfg {
+		symset := newSymbolset()
+		symset.passthrough = c.Passthrough
+		symset.pairs = c.Pairs
+		for bfx, cp := range c.Symbols {
+			symset.set(bfx, cp)
+		}
+		for _, r := range c.Rules {
+			rule, err := parseRule(r)
+			if err != nil {
+				log.Printf("skipping malformed rule for %q: %s", name, err)
+				continue
+			}
+			symset.rules = append(symset.rules, rule)
+		}
+		counterparties[name] = symset
+	}
+	return counterparties
+}
@@ -0,0 +1,72 @@
+package symbol
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// HTTPSymbology fetches symbol mappings from a remote symbology service
+// rather than a local file, for operators who manage mappings centrally.
+type HTTPSymbology struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPSymbology creates a Symbology backed by a remote HTTP service.
+// baseURL is expected to expose:
+//
+//	GET {baseURL}/to-bitfinex?symbol=...&counterparty=...
+//	GET {baseURL}/from-bitfinex?symbol=...&counterparty=...
+//
+// each returning {"symbol": "..."} on success, or a 404 if no mapping exists.
+func NewHTTPSymbology(baseURL string) *HTTPSymbology {
+	return &HTTPSymbology{
+		baseURL: baseURL,
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+type symbolResponse struct {
+	Symbol string `json:"symbol"`
+}
+
+func (h *HTTPSymbology) fetch(path, symbol, counterparty string) (string, error) {
+	u := fmt.Sprintf("%s/%s?symbol=%s&counterparty=%s", h.baseURL, path, url.QueryEscape(symbol), url.QueryEscape(counterparty))
+	resp, err := h.client.Get(u)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("could not find symbol mapping for \"%s\" / \"%s\"", symbol, counterparty)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("symbology service returned status %d for \"%s\" / \"%s\"", resp.StatusCode, symbol, counterparty)
+	}
+
+	var out symbolResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", err
+	}
+	return out.Symbol, nil
+}
+
+// ToBitfinex converts symbol to Bitfinex form.
+func (h *HTTPSymbology) ToBitfinex(symbol, counterparty string) (string, error) {
+	return h.fetch("to-bitfinex", symbol, counterparty)
+}
+
+// FromBitfinex converts symbol from Bitfinex form.
+func (h *HTTPSymbology) FromBitfinex(symbol, counterparty string) (string, error) {
+	return h.fetch("from-bitfinex", symbol, counterparty)
+}
+
+// Translate converts symbol from counterparty src's form to counterparty
+// dst's form, routing through Bitfinex's native symbol.
+func (h *HTTPSymbology) Translate(src, dst, symbol string) (string, error) {
+	return translate(h, src, dst, symbol)
+}
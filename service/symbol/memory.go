@@ -0,0 +1,73 @@
+package symbol
+
+import "fmt"
+
+// MemorySymbology is an in-memory Symbology with no backing file, primarily
+// useful for building fixtures in tests.
+type MemorySymbology struct {
+	counterparties map[string]*symbolset
+}
+
+// NewMemorySymbology creates an empty in-memory symbology.
+func NewMemorySymbology() *MemorySymbology {
+	return &MemorySymbology{counterparties: make(map[string]*symbolset)}
+}
+
+func (m *MemorySymbology) symset(counterparty string) *symbolset {
+	symset, ok := m.counterparties[counterparty]
+	if !ok {
+		symset = newSymbolset()
+		m.counterparties[counterparty] = symset
+	}
+	return symset
+}
+
+// Set registers a Bitfinex symbol <-> counterparty symbol mapping and
+// returns the receiver so calls can be chained.
+func (m *MemorySymbology) Set(counterparty, bitfinexSymbol, counterpartySymbol string) *MemorySymbology {
+	m.symset(counterparty).set(bitfinexSymbol, counterpartySymbol)
+	return m
+}
+
+// SetPassthrough marks a counterparty as passthrough, meaning its symbols
+// are identical to Bitfinex's.
+func (m *MemorySymbology) SetPassthrough(counterparty string) *MemorySymbology {
+	m.symset(counterparty).passthrough = true
+	return m
+}
+
+// ToBitfinex converts symbol to Bitfinex form.
+func (m *MemorySymbology) ToBitfinex(symbol, counterparty string) (string, error) {
+	symset, ok := m.counterparties[counterparty]
+	if !ok {
+		return "", fmt.Errorf("could not find counterparty: %s", counterparty)
+	}
+	if bfx, ok := symset.getReverse(symbol); ok {
+		return bfx, nil
+	}
+	if symset.passthrough {
+		return symbol, nil
+	}
+	return "", fmt.Errorf("could not find Bitfinex symbol mapping \"%s\" for counterparty \"%s\"", symbol, counterparty)
+}
+
+// FromBitfinex converts symbol from Bitfinex form.
+func (m *MemorySymbology) FromBitfinex(symbol, counterparty string) (string, error) {
+	symset, ok := m.counterparties[counterparty]
+	if !ok {
+		return "", fmt.Errorf("could not find counterparty: %s", counterparty)
+	}
+	if sym, ok := symset.get(symbol); ok {
+		return sym, nil
+	}
+	if symset.passthrough {
+		return symbol, nil
+	}
+	return "", fmt.Errorf("could not find symbol \"%s\" for counterparty \"%s\"", symbol, counterparty)
+}
+
+// Translate converts symbol from counterparty src's form to counterparty
+// dst's form, routing through Bitfinex's native symbol.
+func (m *MemorySymbology) Translate(src, dst, symbol string) (string, error) {
+	return translate(m, src, dst, symbol)
+}
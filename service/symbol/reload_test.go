@@ -0,0 +1,94 @@
+package symbol
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadYAML(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "symbology.yaml")
+	body := "Bloomberg:\n  symbols:\n    tBTCUSD: BXY\n"
+	if err := ioutil.WriteFile(path, []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+	counterparties, err := loadYAML(path)
+	if err != nil {
+		t.Fatalf("loadYAML: %s", err)
+	}
+	symset, ok := counterparties["Bloomberg"]
+	if !ok {
+		t.Fatal("expected a Bloomberg counterparty")
+	}
+	if sym, ok := symset.get("tBTCUSD"); !ok || sym != "BXY" {
+		t.Errorf("get(tBTCUSD) = %q, %v, want %q, true", sym, ok, "BXY")
+	}
+}
+
+func TestLoadJSON(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "symbology.json")
+	body := `{"Bloomberg": {"symbols": {"tBTCUSD": "BXY"}}}`
+	if err := ioutil.WriteFile(path, []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+	counterparties, err := loadJSON(path)
+	if err != nil {
+		t.Fatalf("loadJSON: %s", err)
+	}
+	symset, ok := counterparties["Bloomberg"]
+	if !ok {
+		t.Fatal("expected a Bloomberg counterparty")
+	}
+	if sym, ok := symset.get("tBTCUSD"); !ok || sym != "BXY" {
+		t.Errorf("get(tBTCUSD) = %q, %v, want %q, true", sym, ok, "BXY")
+	}
+}
+
+// TestReloadOnAtomicReplace exercises the directory-watch fix: writing a new
+// file over path via rename (the standard atomic-save pattern used by
+// editors and config-management tools) must still trigger a reload and
+// notify subscribers, even though the original inode is replaced.
+func TestReloadOnAtomicReplace(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "symbology.kvp")
+	if err := ioutil.WriteFile(path, []byte("[Bloomberg]\ntBTCUSD=BXY\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := NewFileSymbology(path)
+	if err != nil {
+		t.Fatalf("NewFileSymbology: %s", err)
+	}
+	defer f.Close()
+
+	events := make(chan Event, 1)
+	f.Subscribe(events)
+
+	sym, err := f.FromBitfinex("tBTCUSD", "Bloomberg")
+	if err != nil || sym != "BXY" {
+		t.Fatalf("FromBitfinex before reload = %q, %v, want %q, nil", sym, err, "BXY")
+	}
+
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte("[Bloomberg]\ntBTCUSD=NEWSYM\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-events:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reload event after atomic replace")
+	}
+
+	sym, err = f.FromBitfinex("tBTCUSD", "Bloomberg")
+	if err != nil || sym != "NEWSYM" {
+		t.Fatalf("FromBitfinex after reload = %q, %v, want %q, nil", sym, err, "NEWSYM")
+	}
+}
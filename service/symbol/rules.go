@@ -0,0 +1,52 @@
+package symbol
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// symbolRule is a regex/template mapping rule, e.g. turning tBTCUSD into
+// BTC/USD for a counterparty that uses ISO-style pair notation, without
+// enumerating every pair explicitly. Rules only apply to the
+// Bitfinex-to-counterparty direction; for the inverse, and for ISO-style
+// pairs specifically, see the "pairs" mode backed by CurrencyPair.
+type symbolRule struct {
+	pattern  *regexp.Regexp
+	template string
+}
+
+// parseRule parses a "regex -> template" rule value, e.g.
+// "^t([A-Z]{3})([A-Z]{3})$ -> $1/$2", where $1, $2, ... refer to the
+// regex's capture groups as in regexp.Regexp.Expand.
+func parseRule(value string) (symbolRule, error) {
+	parts := strings.SplitN(value, "->", 2)
+	if len(parts) != 2 {
+		return symbolRule{}, fmt.Errorf("malformed rule %q, expected \"regex -> template\"", value)
+	}
+	pattern, err := regexp.Compile(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return symbolRule{}, err
+	}
+	return symbolRule{pattern: pattern, template: strings.TrimSpace(parts[1])}, nil
+}
+
+// apply expands the rule's template against a match of s, or returns false
+// if s doesn't match the rule's pattern.
+func (r symbolRule) apply(s string) (string, bool) {
+	match := r.pattern.FindStringSubmatchIndex(s)
+	if match == nil {
+		return "", false
+	}
+	return string(r.pattern.ExpandString(nil, r.template, s, match)), true
+}
+
+// matchRules tries each rule in order and returns the first match.
+func matchRules(rules []symbolRule, s string) (string, bool) {
+	for _, rule := range rules {
+		if out, ok := rule.apply(s); ok {
+			return out, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,34 @@
+package symbol
+
+import "testing"
+
+// TestPrecedence verifies that explicit mappings win over rules, and rules
+// win over passthrough.
+func TestPrecedence(t *testing.T) {
+	rule, err := parseRule(`^t([A-Z]{3})([A-Z]{3})$ -> $1/$2`)
+	if err != nil {
+		t.Fatalf("parseRule: %s", err)
+	}
+
+	symset := newSymbolset()
+	symset.passthrough = true
+	symset.rules = []symbolRule{rule}
+	symset.set("tBTCUSD", "BXY") // explicit mapping should win for this symbol
+
+	f := &FileSymbology{counterparties: map[string]*symbolset{"CP": symset}}
+
+	sym, err := f.FromBitfinex("tBTCUSD", "CP")
+	if err != nil || sym != "BXY" {
+		t.Errorf("FromBitfinex(tBTCUSD) = %q, %v, want %q, nil (explicit mapping should win)", sym, err, "BXY")
+	}
+
+	sym, err = f.FromBitfinex("tETHUSD", "CP")
+	if err != nil || sym != "ETH/USD" {
+		t.Errorf("FromBitfinex(tETHUSD) = %q, %v, want %q, nil (rule should win over passthrough)", sym, err, "ETH/USD")
+	}
+
+	sym, err = f.FromBitfinex("unrecognized", "CP")
+	if err != nil || sym != "unrecognized" {
+		t.Errorf("FromBitfinex(unrecognized) = %q, %v, want passthrough", sym, err)
+	}
+}
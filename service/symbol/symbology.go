@@ -0,0 +1,35 @@
+package symbol
+
+// Symbology translates ticker symbols between Bitfinex's native form and a
+// FIX counterparty's own symbology. Gateway services depend on this
+// interface rather than a concrete implementation so operators can supply
+// their own symbol source (file-backed, in-memory, remote, or a composite
+// of several).
+type Symbology interface {
+	// ToBitfinex converts a counterparty's symbol to its Bitfinex form.
+	ToBitfinex(symbol, counterparty string) (string, error)
+	// FromBitfinex converts a Bitfinex symbol to a counterparty's form.
+	FromBitfinex(symbol, counterparty string) (string, error)
+	// Translate converts a symbol from one counterparty's form to another's,
+	// routing src -> Bitfinex -> dst so a FIX session from counterparty src
+	// can be bridged to a Bitfinex feed consumed by counterparty dst without
+	// either counterparty needing a direct mapping to the other.
+	Translate(src, dst, symbol string) (string, error)
+}
+
+// translate implements the src -> Bitfinex -> dst routing shared by every
+// Symbology implementation.
+func translate(s Symbology, src, dst, symbol string) (string, error) {
+	bfx, err := s.ToBitfinex(symbol, src)
+	if err != nil {
+		return "", err
+	}
+	return s.FromBitfinex(bfx, dst)
+}
+
+var (
+	_ Symbology = (*FileSymbology)(nil)
+	_ Symbology = (*MemorySymbology)(nil)
+	_ Symbology = (*HTTPSymbology)(nil)
+	_ Symbology = (*CompositeSymbology)(nil)
+)
@@ -0,0 +1,38 @@
+package symbol
+
+import "testing"
+
+func TestMemorySymbologyTranslate(t *testing.T) {
+	m := NewMemorySymbology().
+		Set("Bloomberg", "tBTCUSD", "BXY").
+		Set("Reuters", "tBTCUSD", "BTCUS")
+
+	sym, err := m.Translate("Bloomberg", "Reuters", "BXY")
+	if err != nil {
+		t.Fatalf("Translate: %s", err)
+	}
+	if sym != "BTCUS" {
+		t.Errorf("Translate(Bloomberg, Reuters, BXY) = %q, want %q", sym, "BTCUS")
+	}
+}
+
+func TestCompositeSymbologyFallback(t *testing.T) {
+	primary := NewMemorySymbology().Set("CP", "tBTCUSD", "BXY")
+	fallback := NewMemorySymbology().Set("CP", "tETHUSD", "ETHUS")
+
+	c := NewCompositeSymbology(primary, fallback)
+
+	sym, err := c.FromBitfinex("tBTCUSD", "CP")
+	if err != nil || sym != "BXY" {
+		t.Errorf("FromBitfinex(tBTCUSD) = %q, %v, want %q from primary", sym, err, "BXY")
+	}
+
+	sym, err = c.FromBitfinex("tETHUSD", "CP")
+	if err != nil || sym != "ETHUS" {
+		t.Errorf("FromBitfinex(tETHUSD) = %q, %v, want %q from fallback", sym, err, "ETHUS")
+	}
+
+	if _, err := c.FromBitfinex("tXRPUSD", "CP"); err == nil {
+		t.Error("expected an error when no source has a mapping")
+	}
+}
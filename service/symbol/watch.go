@@ -0,0 +1,97 @@
+package symbol
+
+import (
+	"log"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// EventType classifies a notification sent to Subscribe-ers.
+type EventType int
+
+const (
+	// EventReload is sent after the symbology file was re-parsed and swapped in.
+	EventReload EventType = iota
+)
+
+// Event is sent to subscribers whenever the underlying symbology mapping changes.
+type Event struct {
+	Type EventType
+	Path string
+}
+
+// Subscribe registers a channel to receive Events whenever the symbology
+// mapping is reloaded from disk. Sends are non-blocking: a subscriber that
+// isn't keeping up misses events rather than stalling the reload.
+func (f *FileSymbology) Subscribe(ch chan Event) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.subscribers = append(f.subscribers, ch)
+}
+
+func (f *FileSymbology) notify(e Event) {
+	f.lock.Lock()
+	subscribers := f.subscribers
+	f.lock.Unlock()
+	for _, ch := range subscribers {
+		select {
+		case ch <- e:
+		default:
+			log.Printf("symbology subscriber is not keeping up, dropping event for %q", e.Path)
+		}
+	}
+}
+
+// fsWatcher wraps fsnotify.Watcher so callers outside this package never
+// need to import fsnotify directly.
+type fsWatcher struct {
+	w *fsnotify.Watcher
+}
+
+func (w *fsWatcher) Close() error {
+	return w.w.Close()
+}
+
+// startWatching watches the directory containing path, rather than path
+// itself, and invokes onChange for any write/create/rename event on an
+// entry matching path's basename. Editors and config-management tools
+// commonly save atomically (write a temp file, then rename it over the
+// target); that replaces the original inode, so a watch on the file itself
+// is silently dropped by the kernel after the very first such edit and
+// never fires again. Watching the parent directory survives replacement.
+func startWatching(path string, onChange func(path string)) (*fsWatcher, error) {
+	dir := filepath.Dir(path)
+	base := filepath.Base(path)
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Add(dir); err != nil {
+		w.Close()
+		return nil, err
+	}
+	go func() {
+		for {
+			select {
+			case event, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				if filepath.Base(event.Name) != base {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+					onChange(path)
+				}
+			case err, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("symbology watcher error for %q: %s", path, err)
+			}
+		}
+	}()
+	return &fsWatcher{w: w}, nil
+}